@@ -0,0 +1,135 @@
+package s3preup
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// UploadConstraints restricts what a presigned PUT will accept. Every
+// populated field is set on the underlying PutObjectInput, and ContentType
+// and ChecksumSHA256 are additionally set on the request just before signing
+// (see forceSignedHeaders) so the AWS SigV4 signature covers them as signed
+// headers — the client must send the exact value back or S3 will reject the
+// request with a signature mismatch. This
+// closes the gap left by a bare PresignedUploadURL, which lets the client PUT
+// any content-type or size it likes.
+//
+// ContentLength, when set, pins the request to that exact object size. S3
+// presigned PUTs sign individual header values rather than ranges, so unlike
+// PostPolicyOptions.MinContentLength/MaxContentLength there is no range form
+// here — callers that need a range should use PresignedUploadPost instead.
+type UploadConstraints struct {
+	ContentType          string
+	ContentLength        int64
+	ContentDisposition   string
+	CacheControl         string
+	ACL                  string
+	ServerSideEncryption string
+
+	// SSECustomerKey is the raw (not base64-encoded) SSE-C key. It is
+	// base64-encoded for the signed x-amz-server-side-encryption-customer-key
+	// header, and its MD5 digest is base64-encoded for the matching
+	// x-amz-server-side-encryption-customer-key-MD5 header.
+	SSECustomerKey string
+
+	Metadata       map[string]string
+	ChecksumSHA256 string
+}
+
+// PresignedUploadURLWithConstraints generates a temporary, secure URL that
+// can be used to PUT an object into S3, requiring the upload to match the
+// given constraints. The expiry duration specifies how long the URL will be
+// valid for.
+func (p *S3Provider) PresignedUploadURLWithConstraints(ctx context.Context, destination string, expires time.Duration, constraints UploadConstraints) (string, error) {
+	key, err := p.SanitizeKey(destination)
+	if err != nil {
+		return "", err
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	}
+
+	if constraints.ContentType != "" {
+		input.ContentType = aws.String(constraints.ContentType)
+	}
+	if constraints.ContentLength > 0 {
+		input.ContentLength = aws.Int64(constraints.ContentLength)
+	}
+	if constraints.ContentDisposition != "" {
+		input.ContentDisposition = aws.String(constraints.ContentDisposition)
+	}
+	if constraints.CacheControl != "" {
+		input.CacheControl = aws.String(constraints.CacheControl)
+	}
+	if constraints.ACL != "" {
+		input.ACL = types.ObjectCannedACL(constraints.ACL)
+	}
+	if constraints.ServerSideEncryption != "" {
+		input.ServerSideEncryption = types.ServerSideEncryption(constraints.ServerSideEncryption)
+	}
+	if constraints.SSECustomerKey != "" {
+		keyBytes := []byte(constraints.SSECustomerKey)
+		keyMD5 := md5.Sum(keyBytes)
+		input.SSECustomerAlgorithm = aws.String("AES256")
+		input.SSECustomerKey = aws.String(base64.StdEncoding.EncodeToString(keyBytes))
+		input.SSECustomerKeyMD5 = aws.String(base64.StdEncoding.EncodeToString(keyMD5[:]))
+	}
+	if len(constraints.Metadata) > 0 {
+		input.Metadata = constraints.Metadata
+	}
+	if constraints.ChecksumSHA256 != "" {
+		input.ChecksumSHA256 = aws.String(constraints.ChecksumSHA256)
+	}
+
+	forcedHeaders := map[string]string{}
+	if constraints.ContentType != "" {
+		forcedHeaders["Content-Type"] = constraints.ContentType
+	}
+	if constraints.ChecksumSHA256 != "" {
+		forcedHeaders["X-Amz-Checksum-Sha256"] = constraints.ChecksumSHA256
+	}
+
+	presignedPutRequest, err := p.presignClient.PresignPutObject(ctx, input, func(po *s3.PresignOptions) {
+		po.Expires = expires
+		if len(forcedHeaders) > 0 {
+			po.ClientOptions = append(po.ClientOptions, forceSignedHeaders(forcedHeaders))
+		}
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return presignedPutRequest.URL, nil
+}
+
+// forceSignedHeaders returns an S3 client option that sets the given headers
+// on the request immediately before the SigV4 signer runs, so they are
+// covered by X-Amz-SignedHeaders regardless of which other fields happen to
+// be set. Without this, PutObjectInput.ContentType and ChecksumSHA256 can end
+// up unsigned depending on what else is present on the request, letting a
+// client substitute different values at upload time without invalidating the
+// signature — defeating the point of UploadConstraints.
+func forceSignedHeaders(headers map[string]string) func(*s3.Options) {
+	return s3.WithAPIOptions(func(stack *middleware.Stack) error {
+		return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("ForceSignedHeaders",
+			func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				if req, ok := in.Request.(*smithyhttp.Request); ok {
+					for k, v := range headers {
+						req.Header.Set(k, v)
+					}
+				}
+				return next.HandleFinalize(ctx, in)
+			}), "Signing", middleware.Before)
+	})
+}
@@ -0,0 +1,170 @@
+package s3preup
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// PresignedUploadURLWithConstraints implementation that uses our custom mock interface
+func (p *testS3Provider) PresignedUploadURLWithConstraints(ctx context.Context, destination string, expires time.Duration, constraints UploadConstraints) (string, error) {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(destination),
+	}
+
+	if constraints.ContentType != "" {
+		input.ContentType = aws.String(constraints.ContentType)
+	}
+	if constraints.ContentLength > 0 {
+		input.ContentLength = aws.Int64(constraints.ContentLength)
+	}
+	if constraints.ACL != "" {
+		input.ACL = types.ObjectCannedACL(constraints.ACL)
+	}
+	if constraints.SSECustomerKey != "" {
+		keyBytes := []byte(constraints.SSECustomerKey)
+		keyMD5 := md5.Sum(keyBytes)
+		input.SSECustomerAlgorithm = aws.String("AES256")
+		input.SSECustomerKey = aws.String(base64.StdEncoding.EncodeToString(keyBytes))
+		input.SSECustomerKeyMD5 = aws.String(base64.StdEncoding.EncodeToString(keyMD5[:]))
+	}
+
+	return p.presignClient.PresignPutObject(ctx, input, func(po *s3.PresignOptions) {
+		po.Expires = expires
+	})
+}
+
+func TestPresignedUploadURLWithConstraints(t *testing.T) {
+	ctx := context.Background()
+	bucket := "test-bucket"
+	destination := "uploads/test-file.txt"
+	expires := 15 * time.Minute
+	expectedURL := "https://test-bucket.s3.amazonaws.com/uploads/test-file.txt"
+
+	t.Run("signs content-type, content-length, and acl as part of the request", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+
+		mockClient.On("PresignPutObject",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+				return *input.Bucket == bucket &&
+					*input.Key == destination &&
+					*input.ContentType == "image/png" &&
+					*input.ContentLength == int64(1024) &&
+					input.ACL == types.ObjectCannedACLPublicRead
+			}),
+			mock.Anything,
+		).Return(expectedURL, nil)
+
+		provider := &testS3Provider{
+			bucket:        bucket,
+			presignClient: mockClient,
+		}
+
+		url, err := provider.PresignedUploadURLWithConstraints(ctx, destination, expires, UploadConstraints{
+			ContentType:   "image/png",
+			ContentLength: 1024,
+			ACL:           string(types.ObjectCannedACLPublicRead),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedURL, url)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("leaves unconstrained fields unset", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+
+		mockClient.On("PresignPutObject",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+				return input.ContentType == nil && input.ContentLength == nil && input.ACL == ""
+			}),
+			mock.Anything,
+		).Return(expectedURL, nil)
+
+		provider := &testS3Provider{
+			bucket:        bucket,
+			presignClient: mockClient,
+		}
+
+		url, err := provider.PresignedUploadURLWithConstraints(ctx, destination, expires, UploadConstraints{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedURL, url)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rejects a traversal key before signing", func(t *testing.T) {
+		p := &S3Provider{bucket: bucket}
+
+		_, err := p.PresignedUploadURLWithConstraints(ctx, "uploads/../secrets.txt", expires, UploadConstraints{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("base64-encodes the SSE-C key and matches its MD5 digest", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+		rawKey := "0123456789abcdef0123456789abcdef"
+		wantKey := base64.StdEncoding.EncodeToString([]byte(rawKey))
+		wantMD5 := md5.Sum([]byte(rawKey))
+
+		mockClient.On("PresignPutObject",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+				return *input.SSECustomerKey == wantKey &&
+					*input.SSECustomerKeyMD5 == base64.StdEncoding.EncodeToString(wantMD5[:]) &&
+					*input.SSECustomerAlgorithm == "AES256"
+			}),
+			mock.Anything,
+		).Return(expectedURL, nil)
+
+		provider := &testS3Provider{
+			bucket:        bucket,
+			presignClient: mockClient,
+		}
+
+		url, err := provider.PresignedUploadURLWithConstraints(ctx, destination, expires, UploadConstraints{
+			SSECustomerKey: rawKey,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedURL, url)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+// TestPresignedUploadURLWithConstraintsSignedHeaders exercises the real SDK
+// presign client (not the mock above, which only asserts against
+// PutObjectInput and can't observe what the SigV4 signer actually signed) to
+// confirm ContentType and ChecksumSHA256 end up in X-Amz-SignedHeaders.
+func TestPresignedUploadURLWithConstraintsSignedHeaders(t *testing.T) {
+	ctx := context.Background()
+	provider := testProviderWithStaticCreds(t, "test-bucket", "us-east-1")
+
+	t.Run("signs content-type and checksum-sha256 against the real SDK", func(t *testing.T) {
+		presigned, err := provider.PresignedUploadURLWithConstraints(ctx, "uploads/test-file.txt", 15*time.Minute, UploadConstraints{
+			ContentType:    "image/png",
+			ChecksumSHA256: "8wM9i5pn+ipaE1ASImczoDwcDLwhGW7mTFxh8CpCLv4=",
+		})
+		require.NoError(t, err)
+
+		parsed, err := url.Parse(presigned)
+		require.NoError(t, err)
+
+		signedHeaders := parsed.Query().Get("X-Amz-SignedHeaders")
+		assert.Contains(t, signedHeaders, "content-type")
+		assert.Contains(t, signedHeaders, "x-amz-checksum-sha256")
+	})
+}
@@ -0,0 +1,62 @@
+package s3preup
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// SanitizeKey normalizes and validates an object key before it is signed,
+// applying the KeyPolicy configured via WithKeyPrefix and WithFilenameRewrite:
+//
+//   - leading slashes are stripped, since aws-sdk-go-v2 no longer does this
+//     and a leading slash produces a literal "//"-prefixed object key
+//   - any ".." path segment is rejected as traversal
+//   - if a required prefix is configured, it is prepended when missing
+//   - if filename rewriting is enabled, the terminal path segment is replaced
+//     with a random identifier, preserving its extension
+//
+// Because the rewrite is random, SanitizeKey must be called at most once per
+// logical upload. CreateMultipartUpload is the only entry point that needs
+// the key again after the fact (PresignUploadPartURL, CompleteMultipartUpload,
+// and AbortMultipartUpload all operate on an already-created upload), so it
+// returns the resolved key for callers to pass back unchanged instead of
+// re-sanitizing it.
+func (p *S3Provider) SanitizeKey(key string) (string, error) {
+	cleaned := strings.TrimLeft(key, "/")
+	if cleaned == "" {
+		return "", fmt.Errorf("s3preup: key must not be empty")
+	}
+
+	for _, segment := range strings.Split(cleaned, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("s3preup: key %q contains a path traversal segment", key)
+		}
+	}
+
+	if p.rewriteFilename {
+		dir, file := path.Split(cleaned)
+		id, err := randomHex(16)
+		if err != nil {
+			return "", err
+		}
+		cleaned = dir + id + path.Ext(file)
+	}
+
+	if p.keyPrefix != "" && !strings.HasPrefix(cleaned, p.keyPrefix) {
+		cleaned = p.keyPrefix + cleaned
+	}
+
+	return cleaned, nil
+}
+
+// randomHex returns n random bytes, hex-encoded.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,76 @@
+package s3preup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeKey(t *testing.T) {
+	t.Run("strips leading slashes", func(t *testing.T) {
+		p := &S3Provider{}
+
+		key, err := p.SanitizeKey("/uploads/file.txt")
+
+		require.NoError(t, err)
+		assert.Equal(t, "uploads/file.txt", key)
+	})
+
+	t.Run("rejects path traversal", func(t *testing.T) {
+		p := &S3Provider{}
+
+		_, err := p.SanitizeKey("uploads/../secrets.txt")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an empty key", func(t *testing.T) {
+		p := &S3Provider{}
+
+		_, err := p.SanitizeKey("///")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("prepends a required prefix when missing", func(t *testing.T) {
+		p := &S3Provider{keyPrefix: "uploads/tenant-42/"}
+
+		key, err := p.SanitizeKey("file.txt")
+
+		require.NoError(t, err)
+		assert.Equal(t, "uploads/tenant-42/file.txt", key)
+	})
+
+	t.Run("does not duplicate a prefix already present", func(t *testing.T) {
+		p := &S3Provider{keyPrefix: "uploads/tenant-42/"}
+
+		key, err := p.SanitizeKey("uploads/tenant-42/file.txt")
+
+		require.NoError(t, err)
+		assert.Equal(t, "uploads/tenant-42/file.txt", key)
+	})
+
+	t.Run("rewrites the filename while preserving the extension", func(t *testing.T) {
+		p := &S3Provider{rewriteFilename: true}
+
+		key, err := p.SanitizeKey("uploads/my file (1).png")
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key, "uploads/"))
+		assert.True(t, strings.HasSuffix(key, ".png"))
+		assert.NotContains(t, key, "my file")
+	})
+
+	t.Run("rewrites the same key differently across calls", func(t *testing.T) {
+		p := &S3Provider{rewriteFilename: true}
+
+		key1, err := p.SanitizeKey("uploads/my file (1).png")
+		require.NoError(t, err)
+		key2, err := p.SanitizeKey("uploads/my file (1).png")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, key1, key2)
+	})
+}
@@ -0,0 +1,110 @@
+package s3preup
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// CompletedPart identifies a single uploaded part by its part number and the
+// ETag S3 returned for it. Callers accumulate these as parts finish
+// uploading and pass the full set to CompleteMultipartUpload.
+type CompletedPart struct {
+	PartNumber int32
+	ETag       string
+}
+
+// MultipartUpload identifies an in-progress multipart upload: the upload ID
+// S3 assigned and the sanitized object key it was created under. Key must be
+// passed unchanged to PresignUploadPartURL, CompleteMultipartUpload, and
+// AbortMultipartUpload — those methods do not re-run SanitizeKey themselves,
+// since doing so would re-apply WithFilenameRewrite's random rewrite and
+// produce a different key than the one the upload was actually created with.
+type MultipartUpload struct {
+	UploadID string
+	Key      string
+}
+
+// CreateMultipartUpload starts a new multipart upload for destination and
+// returns the resulting MultipartUpload, which callers pass back unchanged to
+// PresignUploadPartURL and CompleteMultipartUpload (or AbortMultipartUpload)
+// for the remainder of the upload's lifecycle.
+func (p *S3Provider) CreateMultipartUpload(ctx context.Context, destination string) (MultipartUpload, error) {
+	key, err := p.SanitizeKey(destination)
+	if err != nil {
+		return MultipartUpload{}, err
+	}
+
+	out, err := p.s3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return MultipartUpload{}, err
+	}
+
+	return MultipartUpload{UploadID: aws.ToString(out.UploadId), Key: key}, nil
+}
+
+// PresignUploadPartURL generates a temporary, secure URL that can be used to
+// PUT a single part of a multipart upload. key must be the Key returned by
+// CreateMultipartUpload. partNumber is 1-indexed, per S3's convention.
+func (p *S3Provider) PresignUploadPartURL(ctx context.Context, key, uploadID string, partNumber int32, expires time.Duration) (string, error) {
+	presignedUploadPartRequest, err := p.presignClient.PresignUploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(p.bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(partNumber),
+	}, func(po *s3.PresignOptions) {
+		po.Expires = expires
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return presignedUploadPartRequest.URL, nil
+}
+
+// CompleteMultipartUpload finalizes a multipart upload once every part has
+// been uploaded, stitching the parts together into a single object. key must
+// be the Key returned by CreateMultipartUpload, and parts must be supplied in
+// ascending PartNumber order.
+func (p *S3Provider) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error {
+	completedParts := make([]types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completedParts[i] = types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		}
+	}
+
+	_, err := p.s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(p.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completedParts,
+		},
+	})
+
+	return err
+}
+
+// AbortMultipartUpload cancels an in-progress multipart upload and instructs
+// S3 to discard any parts already uploaded for it. key must be the Key
+// returned by CreateMultipartUpload. Callers should abort uploads they no
+// longer intend to complete to avoid being billed for orphaned parts.
+func (p *S3Provider) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := p.s3Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(p.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+
+	return err
+}
@@ -0,0 +1,275 @@
+package s3preup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// s3ClientAPI matches the subset of s3.Client methods used for multipart uploads.
+type s3ClientAPI interface {
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+}
+
+type mockS3Client struct {
+	mock.Mock
+}
+
+func (m *mockS3Client) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	out, _ := args.Get(0).(*s3.CreateMultipartUploadOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockS3Client) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	out, _ := args.Get(0).(*s3.CompleteMultipartUploadOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockS3Client) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	out, _ := args.Get(0).(*s3.AbortMultipartUploadOutput)
+	return out, args.Error(1)
+}
+
+// testMultipartProvider mirrors S3Provider but accepts our mock s3ClientAPI and
+// presignClientAPI so the multipart methods can be exercised without a real AWS config.
+type testMultipartProvider struct {
+	bucket        string
+	s3Client      s3ClientAPI
+	presignClient presignClientAPI
+}
+
+func (p *testMultipartProvider) CreateMultipartUpload(ctx context.Context, key string) (MultipartUpload, error) {
+	out, err := p.s3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return MultipartUpload{}, err
+	}
+
+	return MultipartUpload{UploadID: aws.ToString(out.UploadId), Key: key}, nil
+}
+
+func (p *testMultipartProvider) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error {
+	completedParts := make([]types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completedParts[i] = types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		}
+	}
+
+	_, err := p.s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(p.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completedParts,
+		},
+	})
+
+	return err
+}
+
+func (p *testMultipartProvider) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := p.s3Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(p.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+
+	return err
+}
+
+func TestCreateMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	bucket := "test-bucket"
+	key := "uploads/large-file.zip"
+	expectedUploadID := "upload-id-123"
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockClient := new(mockS3Client)
+
+		mockClient.On("CreateMultipartUpload",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.CreateMultipartUploadInput) bool {
+				return *input.Bucket == bucket && *input.Key == key
+			}),
+			mock.Anything,
+		).Return(&s3.CreateMultipartUploadOutput{UploadId: aws.String(expectedUploadID)}, nil)
+
+		provider := &testMultipartProvider{bucket: bucket, s3Client: mockClient}
+
+		upload, err := provider.CreateMultipartUpload(ctx, key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedUploadID, upload.UploadID)
+		assert.Equal(t, key, upload.Key)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("error during creation", func(t *testing.T) {
+		mockClient := new(mockS3Client)
+		expectedError := errors.New("create multipart upload error")
+
+		mockClient.On("CreateMultipartUpload", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, expectedError)
+
+		provider := &testMultipartProvider{bucket: bucket, s3Client: mockClient}
+
+		upload, err := provider.CreateMultipartUpload(ctx, key)
+
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+		assert.Empty(t, upload.UploadID)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestPresignUploadPartURL(t *testing.T) {
+	ctx := context.Background()
+	bucket := "test-bucket"
+	key := "uploads/large-file.zip"
+	uploadID := "upload-id-123"
+	expires := 15 * time.Minute
+	expectedURL := "https://test-bucket.s3.amazonaws.com/uploads/large-file.zip?partNumber=1"
+
+	t.Run("successful presigned URL generation", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+
+		mockClient.On("PresignUploadPart",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.UploadPartInput) bool {
+				return *input.Bucket == bucket && *input.Key == key && *input.UploadId == uploadID && *input.PartNumber == int32(1)
+			}),
+			mock.Anything,
+		).Return(expectedURL, nil)
+
+		provider := &testS3Provider{bucket: bucket, presignClient: mockClient}
+
+		url, err := provider.PresignUploadPartURL(ctx, key, uploadID, 1, expires)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedURL, url)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCompleteMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	bucket := "test-bucket"
+	key := "uploads/large-file.zip"
+	uploadID := "upload-id-123"
+	parts := []CompletedPart{
+		{PartNumber: 1, ETag: "etag-1"},
+		{PartNumber: 2, ETag: "etag-2"},
+	}
+
+	t.Run("successful completion", func(t *testing.T) {
+		mockClient := new(mockS3Client)
+
+		mockClient.On("CompleteMultipartUpload",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.CompleteMultipartUploadInput) bool {
+				return *input.Bucket == bucket && *input.Key == key && *input.UploadId == uploadID &&
+					len(input.MultipartUpload.Parts) == 2
+			}),
+			mock.Anything,
+		).Return(&s3.CompleteMultipartUploadOutput{}, nil)
+
+		provider := &testMultipartProvider{bucket: bucket, s3Client: mockClient}
+
+		err := provider.CompleteMultipartUpload(ctx, key, uploadID, parts)
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("error during completion", func(t *testing.T) {
+		mockClient := new(mockS3Client)
+		expectedError := errors.New("complete multipart upload error")
+
+		mockClient.On("CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, expectedError)
+
+		provider := &testMultipartProvider{bucket: bucket, s3Client: mockClient}
+
+		err := provider.CompleteMultipartUpload(ctx, key, uploadID, parts)
+
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestAbortMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	bucket := "test-bucket"
+	key := "uploads/large-file.zip"
+	uploadID := "upload-id-123"
+
+	t.Run("successful abort", func(t *testing.T) {
+		mockClient := new(mockS3Client)
+
+		mockClient.On("AbortMultipartUpload",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.AbortMultipartUploadInput) bool {
+				return *input.Bucket == bucket && *input.Key == key && *input.UploadId == uploadID
+			}),
+			mock.Anything,
+		).Return(&s3.AbortMultipartUploadOutput{}, nil)
+
+		provider := &testMultipartProvider{bucket: bucket, s3Client: mockClient}
+
+		err := provider.AbortMultipartUpload(ctx, key, uploadID)
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("error during abort", func(t *testing.T) {
+		mockClient := new(mockS3Client)
+		expectedError := errors.New("abort multipart upload error")
+
+		mockClient.On("AbortMultipartUpload", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, expectedError)
+
+		provider := &testMultipartProvider{bucket: bucket, s3Client: mockClient}
+
+		err := provider.AbortMultipartUpload(ctx, key, uploadID)
+
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+// TestCreateMultipartUploadSanitizesKey verifies CreateMultipartUpload routes
+// its destination through SanitizeKey before touching the network, by
+// checking that a traversal key is rejected by a zero-value S3Provider (which
+// would panic on a nil s3Client if the call reached that far). The other
+// three multipart methods intentionally do not re-sanitize: they take the Key
+// already resolved by CreateMultipartUpload, and re-running SanitizeKey would
+// re-apply WithFilenameRewrite's random rewrite and diverge from the key the
+// upload was created under.
+func TestCreateMultipartUploadSanitizesKey(t *testing.T) {
+	ctx := context.Background()
+	p := &S3Provider{bucket: "test-bucket"}
+	badKey := "uploads/../secrets.txt"
+
+	_, err := p.CreateMultipartUpload(ctx, badKey)
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package s3preup
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Option configures an S3Provider constructed via NewWithOptions. Options are
+// applied in the order given, so a later option can override an earlier one.
+type Option func(*providerConfig)
+
+// providerConfig accumulates the settings controlled by Option before the
+// underlying AWS clients are built.
+type providerConfig struct {
+	region          string
+	endpoint        string
+	pathStyle       bool
+	httpClient      *http.Client
+	credsProvider   aws.CredentialsProvider
+	keyPrefix       string
+	rewriteFilename bool
+}
+
+// WithRegion sets the AWS region used for request signing. Required by most
+// S3-compatible backends even when WithEndpoint points elsewhere.
+func WithRegion(region string) Option {
+	return func(c *providerConfig) {
+		c.region = region
+	}
+}
+
+// WithEndpoint overrides the base endpoint the S3 client talks to, for
+// S3-compatible backends such as MinIO, Cloudflare R2, Tigris, or Backblaze B2.
+func WithEndpoint(url string) Option {
+	return func(c *providerConfig) {
+		c.endpoint = url
+	}
+}
+
+// WithStaticCredentials pins the provider to a fixed access key, secret key,
+// and (optional) session token instead of the default AWS credential chain.
+func WithStaticCredentials(accessKey, secretKey, sessionToken string) Option {
+	return func(c *providerConfig) {
+		c.credsProvider = credentials.NewStaticCredentialsProvider(accessKey, secretKey, sessionToken)
+	}
+}
+
+// WithPathStyle switches the client between virtual-hosted-style
+// ("bucket.host/key") and path-style ("host/bucket/key") addressing.
+// Most S3-compatible backends require path-style to be enabled.
+func WithPathStyle(pathStyle bool) Option {
+	return func(c *providerConfig) {
+		c.pathStyle = pathStyle
+	}
+}
+
+// WithHTTPClient supplies a custom *http.Client for the underlying S3 client
+// to use, e.g. to configure timeouts, proxies, or TLS settings.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *providerConfig) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithKeyPrefix requires every key passed to PresignedUploadURL to live under
+// prefix, prepending it automatically when the caller's key doesn't already
+// start with it. Any templating (e.g. substituting a tenant ID into
+// "uploads/{tenant}/") is the caller's responsibility; the prefix is used verbatim.
+func WithKeyPrefix(prefix string) Option {
+	return func(c *providerConfig) {
+		c.keyPrefix = prefix
+	}
+}
+
+// WithFilenameRewrite, when enabled, replaces the terminal filename component
+// of every key passed to PresignedUploadURL with a random identifier,
+// preserving its extension. This avoids collisions and strips
+// caller-controlled names (and anything embedded in them) from the stored key.
+// Because the rewrite is random, see MultipartUpload for how the multipart
+// methods avoid calling SanitizeKey more than once per upload.
+func WithFilenameRewrite(enabled bool) Option {
+	return func(c *providerConfig) {
+		c.rewriteFilename = enabled
+	}
+}
+
+// NewWithOptions creates and configures a new S3Provider using functional
+// options, allowing callers to target S3-compatible backends (MinIO, R2,
+// Tigris, GCS, etc.) instead of being locked to AWS with default credentials.
+func NewWithOptions(ctx context.Context, bucket string, opts ...Option) (*S3Provider, error) {
+	pc := &providerConfig{}
+	for _, opt := range opts {
+		opt(pc)
+	}
+
+	configOpts := []func(*config.LoadOptions) error{}
+	if pc.region != "" {
+		configOpts = append(configOpts, config.WithRegion(pc.region))
+	}
+	if pc.credsProvider != nil {
+		configOpts = append(configOpts, config.WithCredentialsProvider(pc.credsProvider))
+	}
+	if pc.httpClient != nil {
+		configOpts = append(configOpts, config.WithHTTPClient(pc.httpClient))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if pc.endpoint != "" {
+			o.BaseEndpoint = aws.String(pc.endpoint)
+		}
+		o.UsePathStyle = pc.pathStyle
+	})
+	presignClient := s3.NewPresignClient(s3Client)
+
+	return &S3Provider{
+		bucket:          bucket,
+		cfg:             cfg,
+		s3Client:        s3Client,
+		presignClient:   presignClient,
+		keyPrefix:       pc.keyPrefix,
+		rewriteFilename: pc.rewriteFilename,
+	}, nil
+}
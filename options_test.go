@@ -0,0 +1,56 @@
+package s3preup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies endpoint, region, path-style, and static credentials", func(t *testing.T) {
+		provider, err := NewWithOptions(ctx, "test-bucket",
+			WithRegion("us-east-1"),
+			WithEndpoint("https://minio.example.com"),
+			WithPathStyle(true),
+			WithStaticCredentials("access-key", "secret-key", ""),
+		)
+
+		require.NoError(t, err)
+		require.NotNil(t, provider)
+		assert.Equal(t, "test-bucket", provider.bucket)
+		assert.Equal(t, "us-east-1", provider.cfg.Region)
+
+		clientOpts := provider.s3Client.Options()
+		require.NotNil(t, clientOpts.BaseEndpoint)
+		assert.Equal(t, "https://minio.example.com", *clientOpts.BaseEndpoint)
+		assert.True(t, clientOpts.UsePathStyle)
+
+		creds, err := provider.cfg.Credentials.Retrieve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "access-key", creds.AccessKeyID)
+		assert.Equal(t, "secret-key", creds.SecretAccessKey)
+	})
+
+	t.Run("works with no options", func(t *testing.T) {
+		provider, err := NewWithOptions(ctx, "test-bucket")
+
+		require.NoError(t, err)
+		require.NotNil(t, provider)
+		assert.Equal(t, "test-bucket", provider.bucket)
+	})
+
+	t.Run("applies key policy options", func(t *testing.T) {
+		provider, err := NewWithOptions(ctx, "test-bucket",
+			WithKeyPrefix("uploads/tenant-42/"),
+			WithFilenameRewrite(true),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "uploads/tenant-42/", provider.keyPrefix)
+		assert.True(t, provider.rewriteFilename)
+	})
+}
@@ -0,0 +1,191 @@
+package s3preup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+)
+
+// PresignedPost is the result of PresignedUploadPost: a POST target URL and
+// the form fields a browser must submit alongside the file field in order
+// for S3 to accept the upload.
+type PresignedPost struct {
+	URL    string
+	Fields map[string]string
+}
+
+// PostPolicyOptions constrains a browser-based POST upload produced by
+// PresignedUploadPost. Each populated field adds a matching condition to the
+// signed S3 POST policy, so the upload is rejected unless the submitted form
+// satisfies it.
+type PostPolicyOptions struct {
+	// Expires is how long the policy document remains valid for. Defaults
+	// to 15 minutes if zero.
+	Expires time.Duration
+
+	// MinContentLength and MaxContentLength bound the uploaded object size
+	// in bytes. Leave both zero to skip the content-length-range condition.
+	MinContentLength int64
+	MaxContentLength int64
+
+	// ContentTypePrefix restricts uploads to content types starting with
+	// this prefix (e.g. "image/"). Empty disables the condition.
+	ContentTypePrefix string
+
+	// ACL, set when non-empty, is pinned to a single canned ACL value.
+	ACL string
+
+	// CacheControl, set when non-empty, is pinned to a single Cache-Control value.
+	CacheControl string
+
+	// Metadata is pinned to the given x-amz-meta-* values.
+	Metadata map[string]string
+}
+
+// PresignedUploadPost returns a URL and a set of form fields suitable for a
+// browser <form enctype="multipart/form-data"> upload directly to S3. Unlike
+// a presigned PUT, the constraints in opts are enforced by S3 itself at
+// upload time because they are baked into the signed policy document.
+func (p *S3Provider) PresignedUploadPost(ctx context.Context, key string, opts PostPolicyOptions) (*PresignedPost, error) {
+	expires := opts.Expires
+	if expires <= 0 {
+		expires = 15 * time.Minute
+	}
+
+	key, err := p.SanitizeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := p.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+	region := p.cfg.Region
+	credentialScope := fmt.Sprintf("%s/%s/s3/aws4_request", shortDate, region)
+	credential := fmt.Sprintf("%s/%s", creds.AccessKeyID, credentialScope)
+
+	fields := map[string]string{
+		"key":              key,
+		"x-amz-date":       amzDate,
+		"x-amz-credential": credential,
+		"x-amz-algorithm":  "AWS4-HMAC-SHA256",
+	}
+	if creds.SessionToken != "" {
+		fields["x-amz-security-token"] = creds.SessionToken
+	}
+	if opts.ACL != "" {
+		fields["acl"] = opts.ACL
+	}
+	if opts.CacheControl != "" {
+		fields["Cache-Control"] = opts.CacheControl
+	}
+	for k, v := range opts.Metadata {
+		fields["x-amz-meta-"+k] = v
+	}
+
+	conditions := []interface{}{
+		map[string]string{"bucket": p.bucket},
+		[]interface{}{"eq", "$key", key},
+		map[string]string{"x-amz-date": amzDate},
+		map[string]string{"x-amz-credential": credential},
+		map[string]string{"x-amz-algorithm": "AWS4-HMAC-SHA256"},
+	}
+	if creds.SessionToken != "" {
+		conditions = append(conditions, map[string]string{"x-amz-security-token": creds.SessionToken})
+	}
+	if opts.ACL != "" {
+		conditions = append(conditions, map[string]string{"acl": opts.ACL})
+	}
+	if opts.CacheControl != "" {
+		conditions = append(conditions, map[string]string{"Cache-Control": opts.CacheControl})
+	}
+	for k, v := range opts.Metadata {
+		conditions = append(conditions, map[string]string{"x-amz-meta-" + k: v})
+	}
+	if opts.ContentTypePrefix != "" {
+		conditions = append(conditions, []interface{}{"starts-with", "$Content-Type", opts.ContentTypePrefix})
+	}
+	if opts.MinContentLength > 0 || opts.MaxContentLength > 0 {
+		maxContentLength := opts.MaxContentLength
+		if maxContentLength <= 0 {
+			maxContentLength = math.MaxInt64
+		}
+		conditions = append(conditions, []interface{}{"content-length-range", opts.MinContentLength, maxContentLength})
+	}
+
+	policy := map[string]interface{}{
+		"expiration": now.Add(expires).Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	policyBase64 := base64.StdEncoding.EncodeToString(policyJSON)
+
+	signature := signPostPolicy(creds.SecretAccessKey, shortDate, region, policyBase64)
+
+	fields["policy"] = policyBase64
+	fields["x-amz-signature"] = signature
+
+	postURL, err := p.postPolicyURL(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedPost{URL: postURL, Fields: fields}, nil
+}
+
+// postPolicyURL returns the POST target for PresignedUploadPost, honoring
+// the BaseEndpoint/UsePathStyle configured on p.s3Client (see WithEndpoint
+// and WithPathStyle) instead of assuming AWS's own virtual-hosted endpoint.
+func (p *S3Provider) postPolicyURL(region string) (string, error) {
+	opts := p.s3Client.Options()
+
+	if opts.BaseEndpoint == nil || *opts.BaseEndpoint == "" {
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", p.bucket, region), nil
+	}
+
+	endpoint, err := url.Parse(*opts.BaseEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.UsePathStyle {
+		return fmt.Sprintf("%s://%s/%s/", endpoint.Scheme, endpoint.Host, p.bucket), nil
+	}
+
+	return fmt.Sprintf("%s://%s.%s/", endpoint.Scheme, p.bucket, endpoint.Host), nil
+}
+
+// signPostPolicy derives the AWS SigV4 signing key for the given date/region
+// and uses it to sign the base64-encoded policy document, returning the
+// hex-encoded signature expected in the x-amz-signature form field.
+func signPostPolicy(secretAccessKey, shortDate, region, policyBase64 string) string {
+	kDate := hmacSHA256([]byte("AWS4"+secretAccessKey), shortDate)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, "s3")
+	kSigning := hmacSHA256(kService, "aws4_request")
+
+	signature := hmacSHA256(kSigning, policyBase64)
+	return hex.EncodeToString(signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
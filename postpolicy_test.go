@@ -0,0 +1,151 @@
+package s3preup
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProviderWithStaticCreds(t *testing.T, bucket, region string) *S3Provider {
+	t.Helper()
+
+	cfg := aws.Config{
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secretkey", ""),
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	return &S3Provider{
+		bucket:        bucket,
+		cfg:           cfg,
+		s3Client:      s3Client,
+		presignClient: s3.NewPresignClient(s3Client),
+	}
+}
+
+func TestPresignedUploadPost(t *testing.T) {
+	ctx := context.Background()
+	provider := testProviderWithStaticCreds(t, "test-bucket", "us-east-1")
+
+	t.Run("includes expected fields and conditions", func(t *testing.T) {
+		post, err := provider.PresignedUploadPost(ctx, "uploads/avatar.png", PostPolicyOptions{
+			Expires:           5 * time.Minute,
+			MinContentLength:  1,
+			MaxContentLength:  1 << 20,
+			ContentTypePrefix: "image/",
+			ACL:               "public-read",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://test-bucket.s3.us-east-1.amazonaws.com/", post.URL)
+		assert.Equal(t, "uploads/avatar.png", post.Fields["key"])
+		assert.Equal(t, "public-read", post.Fields["acl"])
+		assert.Equal(t, "AWS4-HMAC-SHA256", post.Fields["x-amz-algorithm"])
+		assert.NotEmpty(t, post.Fields["policy"])
+		assert.NotEmpty(t, post.Fields["x-amz-signature"])
+
+		decoded, err := base64.StdEncoding.DecodeString(post.Fields["policy"])
+		require.NoError(t, err)
+
+		var policy struct {
+			Conditions []interface{} `json:"conditions"`
+		}
+		require.NoError(t, json.Unmarshal(decoded, &policy))
+
+		foundRange := false
+		foundPrefix := false
+		for _, c := range policy.Conditions {
+			if arr, ok := c.([]interface{}); ok && len(arr) > 0 {
+				if arr[0] == "content-length-range" {
+					foundRange = true
+				}
+				if arr[0] == "starts-with" {
+					foundPrefix = true
+				}
+			}
+		}
+		assert.True(t, foundRange, "expected a content-length-range condition")
+		assert.True(t, foundPrefix, "expected a starts-with condition")
+	})
+
+	t.Run("defaults expiry when unset", func(t *testing.T) {
+		post, err := provider.PresignedUploadPost(ctx, "uploads/file.txt", PostPolicyOptions{})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, post.Fields["policy"])
+	})
+
+	t.Run("rejects a traversal key before signing", func(t *testing.T) {
+		_, err := provider.PresignedUploadPost(ctx, "uploads/../secrets.txt", PostPolicyOptions{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("defaults an unset max to an unbounded upper limit", func(t *testing.T) {
+		post, err := provider.PresignedUploadPost(ctx, "uploads/file.txt", PostPolicyOptions{
+			MinContentLength: 1024,
+		})
+
+		require.NoError(t, err)
+
+		decoded, err := base64.StdEncoding.DecodeString(post.Fields["policy"])
+		require.NoError(t, err)
+
+		var policy struct {
+			Conditions []interface{} `json:"conditions"`
+		}
+		require.NoError(t, json.Unmarshal(decoded, &policy))
+
+		for _, c := range policy.Conditions {
+			arr, ok := c.([]interface{})
+			if !ok || len(arr) == 0 || arr[0] != "content-length-range" {
+				continue
+			}
+			assert.EqualValues(t, 1024, arr[1])
+			assert.Greater(t, arr[2], float64(1024))
+			return
+		}
+		t.Fatal("expected a content-length-range condition")
+	})
+
+	t.Run("derives the URL from a custom path-style endpoint", func(t *testing.T) {
+		minioProvider, err := NewWithOptions(ctx, "test-bucket",
+			WithRegion("us-east-1"),
+			WithEndpoint("https://minio.example.com"),
+			WithPathStyle(true),
+			WithStaticCredentials("access-key", "secret-key", ""),
+		)
+		require.NoError(t, err)
+
+		post, err := minioProvider.PresignedUploadPost(ctx, "uploads/file.txt", PostPolicyOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://minio.example.com/test-bucket/", post.URL)
+	})
+}
+
+func TestSignPostPolicy(t *testing.T) {
+	t.Run("is deterministic for the same inputs", func(t *testing.T) {
+		sig1 := signPostPolicy("secretkey", "20260101", "us-east-1", "cG9saWN5")
+		sig2 := signPostPolicy("secretkey", "20260101", "us-east-1", "cG9saWN5")
+
+		assert.Equal(t, sig1, sig2)
+		assert.Len(t, sig1, 64)
+	})
+
+	t.Run("differs when the policy document changes", func(t *testing.T) {
+		sig1 := signPostPolicy("secretkey", "20260101", "us-east-1", "cG9saWN5")
+		sig2 := signPostPolicy("secretkey", "20260101", "us-east-1", "ZGlmZmVyZW50")
+
+		assert.NotEqual(t, sig1, sig2)
+	})
+}
@@ -14,11 +14,26 @@ type UploadProvider interface {
 	PresignedUploadURL(ctx context.Context, destination string, expires time.Duration) (string, error)
 }
 
+// DownloadProvider contract for generating presigned URLs for reading and
+// removing objects. It is kept separate from UploadProvider so callers that
+// only need one direction aren't forced to depend on the other.
+type DownloadProvider interface {
+	PresignedDownloadURL(ctx context.Context, key string, expires time.Duration) (string, error)
+	PresignedDeleteURL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
 // S3Provider implements the UploadProvider interface for AWS S3.
 // It holds a pre-configured S3 presign client for efficient reuse.
 type S3Provider struct {
 	bucket        string
+	cfg           aws.Config
+	s3Client      *s3.Client
 	presignClient *s3.PresignClient
+
+	// keyPrefix and rewriteFilename configure the KeyPolicy applied by
+	// SanitizeKey; see WithKeyPrefix and WithFilenameRewrite.
+	keyPrefix       string
+	rewriteFilename bool
 }
 
 // New creates and configures a new S3Provider.
@@ -36,6 +51,8 @@ func New(ctx context.Context, bucket string, region string) (*S3Provider, error)
 
 	return &S3Provider{
 		bucket:        bucket,
+		cfg:           cfg,
+		s3Client:      s3Client,
 		presignClient: presignClient,
 	}, nil
 }
@@ -44,9 +61,14 @@ func New(ctx context.Context, bucket string, region string) (*S3Provider, error)
 // The destination is the full object key (e.g., "uploads/my-file.zip").
 // The expiry duration specifies how long the URL will be valid for.
 func (p *S3Provider) PresignedUploadURL(ctx context.Context, destination string, expires time.Duration) (string, error) {
+	key, err := p.SanitizeKey(destination)
+	if err != nil {
+		return "", err
+	}
+
 	presignedPutRequest, err := p.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(p.bucket),
-		Key:    aws.String(destination),
+		Key:    aws.String(key),
 	}, func(po *s3.PresignOptions) {
 		po.Expires = expires
 	})
@@ -57,3 +79,55 @@ func (p *S3Provider) PresignedUploadURL(ctx context.Context, destination string,
 
 	return presignedPutRequest.URL, nil
 }
+
+// PresignedDownloadURL generates a temporary, secure URL that can be used to GET an object from S3.
+// The key is the full object key (e.g., "uploads/my-file.zip").
+// The expiry duration specifies how long the URL will be valid for.
+func (p *S3Provider) PresignedDownloadURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	// The key names an existing object, so the filename rewrite is not applied.
+	existing := *p
+	existing.rewriteFilename = false
+	key, err := existing.SanitizeKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	presignedGetRequest, err := p.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	}, func(po *s3.PresignOptions) {
+		po.Expires = expires
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return presignedGetRequest.URL, nil
+}
+
+// PresignedDeleteURL generates a temporary, secure URL that can be used to DELETE an object from S3.
+// The key is the full object key (e.g., "uploads/my-file.zip").
+// The expiry duration specifies how long the URL will be valid for.
+func (p *S3Provider) PresignedDeleteURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	// The key names an existing object, so the filename rewrite is not applied.
+	existing := *p
+	existing.rewriteFilename = false
+	key, err := existing.SanitizeKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	presignedDeleteRequest, err := p.presignClient.PresignDeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	}, func(po *s3.PresignOptions) {
+		po.Expires = expires
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return presignedDeleteRequest.URL, nil
+}
@@ -15,6 +15,9 @@ import (
 // Create a custom interface that matches the methods we need from the presign client
 type presignClientAPI interface {
 	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (string, error)
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (string, error)
+	PresignDeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.PresignOptions)) (string, error)
+	PresignUploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.PresignOptions)) (string, error)
 }
 
 // mockPresignClient is a mock implementation of our custom presignClientAPI
@@ -32,6 +35,36 @@ func (m *mockPresignClient) PresignPutObject(
 	return args.String(0), args.Error(1)
 }
 
+// PresignGetObject mock implementation that returns a URL string directly
+func (m *mockPresignClient) PresignGetObject(
+	ctx context.Context,
+	params *s3.GetObjectInput,
+	optFns ...func(*s3.PresignOptions),
+) (string, error) {
+	args := m.Called(ctx, params, optFns)
+	return args.String(0), args.Error(1)
+}
+
+// PresignDeleteObject mock implementation that returns a URL string directly
+func (m *mockPresignClient) PresignDeleteObject(
+	ctx context.Context,
+	params *s3.DeleteObjectInput,
+	optFns ...func(*s3.PresignOptions),
+) (string, error) {
+	args := m.Called(ctx, params, optFns)
+	return args.String(0), args.Error(1)
+}
+
+// PresignUploadPart mock implementation that returns a URL string directly
+func (m *mockPresignClient) PresignUploadPart(
+	ctx context.Context,
+	params *s3.UploadPartInput,
+	optFns ...func(*s3.PresignOptions),
+) (string, error) {
+	args := m.Called(ctx, params, optFns)
+	return args.String(0), args.Error(1)
+}
+
 // TestNew tests the New function for creating an S3Provider
 func TestNew(t *testing.T) {
 	ctx := context.Background()
@@ -42,6 +75,7 @@ func TestNew(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, provider)
 		assert.Equal(t, "test-bucket", provider.bucket)
+		assert.NotNil(t, provider.s3Client)
 		assert.NotNil(t, provider.presignClient)
 	})
 }
@@ -62,6 +96,26 @@ func (p *testS3Provider) PresignedUploadURL(ctx context.Context, destination str
 	})
 }
 
+// PresignedDownloadURL implementation that uses our custom mock interface
+func (p *testS3Provider) PresignedDownloadURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return p.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	}, func(po *s3.PresignOptions) {
+		po.Expires = expires
+	})
+}
+
+// PresignedDeleteURL implementation that uses our custom mock interface
+func (p *testS3Provider) PresignedDeleteURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return p.presignClient.PresignDeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	}, func(po *s3.PresignOptions) {
+		po.Expires = expires
+	})
+}
+
 // TestPresignedUploadURL tests the PresignedUploadURL method
 func TestPresignedUploadURL(t *testing.T) {
 	ctx := context.Background()
@@ -116,3 +170,125 @@ func TestPresignedUploadURL(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 }
+
+// PresignUploadPartURL implementation that uses our custom mock interface
+func (p *testS3Provider) PresignUploadPartURL(ctx context.Context, key, uploadID string, partNumber int32, expires time.Duration) (string, error) {
+	return p.presignClient.PresignUploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(p.bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(partNumber),
+	}, func(po *s3.PresignOptions) {
+		po.Expires = expires
+	})
+}
+
+// TestPresignedDownloadURL tests the PresignedDownloadURL method
+func TestPresignedDownloadURL(t *testing.T) {
+	ctx := context.Background()
+	bucket := "test-bucket"
+	key := "uploads/test-file.txt"
+	expires := 15 * time.Minute
+	expectedURL := "https://test-bucket.s3.amazonaws.com/uploads/test-file.txt"
+
+	t.Run("successful presigned URL generation", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+
+		mockClient.On("PresignGetObject",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+				return *input.Bucket == bucket && *input.Key == key
+			}),
+			mock.Anything,
+		).Return(expectedURL, nil)
+
+		provider := &testS3Provider{
+			bucket:        bucket,
+			presignClient: mockClient,
+		}
+
+		url, err := provider.PresignedDownloadURL(ctx, key, expires)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedURL, url)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("error during presigned URL generation", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+		expectedError := errors.New("presign error")
+
+		mockClient.On("PresignGetObject",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return("", expectedError)
+
+		provider := &testS3Provider{
+			bucket:        bucket,
+			presignClient: mockClient,
+		}
+
+		url, err := provider.PresignedDownloadURL(ctx, key, expires)
+
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+		assert.Empty(t, url)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+// TestPresignedDeleteURL tests the PresignedDeleteURL method
+func TestPresignedDeleteURL(t *testing.T) {
+	ctx := context.Background()
+	bucket := "test-bucket"
+	key := "uploads/test-file.txt"
+	expires := 15 * time.Minute
+	expectedURL := "https://test-bucket.s3.amazonaws.com/uploads/test-file.txt"
+
+	t.Run("successful presigned URL generation", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+
+		mockClient.On("PresignDeleteObject",
+			mock.Anything,
+			mock.MatchedBy(func(input *s3.DeleteObjectInput) bool {
+				return *input.Bucket == bucket && *input.Key == key
+			}),
+			mock.Anything,
+		).Return(expectedURL, nil)
+
+		provider := &testS3Provider{
+			bucket:        bucket,
+			presignClient: mockClient,
+		}
+
+		url, err := provider.PresignedDeleteURL(ctx, key, expires)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedURL, url)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("error during presigned URL generation", func(t *testing.T) {
+		mockClient := new(mockPresignClient)
+		expectedError := errors.New("presign error")
+
+		mockClient.On("PresignDeleteObject",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return("", expectedError)
+
+		provider := &testS3Provider{
+			bucket:        bucket,
+			presignClient: mockClient,
+		}
+
+		url, err := provider.PresignedDeleteURL(ctx, key, expires)
+
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+		assert.Empty(t, url)
+		mockClient.AssertExpectations(t)
+	})
+}